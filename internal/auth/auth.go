@@ -0,0 +1,12 @@
+// Package auth is a minimal stand-in for an application subsystem, used
+// only to exercise vmodule matching against a caller outside the logger
+// package in tests.
+package auth
+
+import "github.com/Yaanthrik/sdk/logger"
+
+// LogDebug emits a DEBUG-level log entry from this package, so tests can
+// verify a vmodule rule like "auth/*=DEBUG" actually applies to it.
+func LogDebug(msg string) {
+	logger.Debug(msg, nil)
+}
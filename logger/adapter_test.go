@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// withCaptureConfig installs a captureWriter as the sole sink for the
+// duration of the test and restores the previous global config afterward.
+func withCaptureConfig(t *testing.T) *captureWriter {
+	t.Helper()
+	prev := globalConfig
+	t.Cleanup(func() { globalConfig = prev })
+
+	cw := &captureWriter{}
+	globalConfig = Config{
+		Writers:  []Writer{cw},
+		LogLevel: DEBUG,
+	}
+	return cw
+}
+
+func TestStdLoggerRoutesThroughPipelineAtInfo(t *testing.T) {
+	cw := withCaptureConfig(t)
+
+	StdLogger().Print("hello from stdlib log\n")
+
+	got := cw.last()
+	if got.Level != INFO {
+		t.Errorf("Level = %q, want %q", got.Level, INFO)
+	}
+	if got.Message != "hello from stdlib log" {
+		t.Errorf("Message = %q, want trimmed trailing newline", got.Message)
+	}
+}
+
+func TestSlogHandlerTranslatesLevelAndAttrs(t *testing.T) {
+	cw := withCaptureConfig(t)
+
+	l := slog.New(SlogHandler())
+	l.Warn("disk low", "free_bytes", 1024)
+
+	got := cw.last()
+	if got.Level != WARN {
+		t.Errorf("Level = %q, want %q", got.Level, WARN)
+	}
+	if got.Message != "disk low" {
+		t.Errorf("Message = %q, want %q", got.Message, "disk low")
+	}
+	if got.Payload["free_bytes"] != int64(1024) {
+		t.Errorf("Payload[free_bytes] = %v, want 1024", got.Payload["free_bytes"])
+	}
+}
+
+func TestSlogHandlerWithGroupPrefixesKeys(t *testing.T) {
+	cw := withCaptureConfig(t)
+
+	l := slog.New(SlogHandler()).WithGroup("req").With("id", "abc")
+	l.Info("handled")
+
+	got := cw.last()
+	if got.Payload["req.id"] != "abc" {
+		t.Errorf("Payload[req.id] = %v, want %q", got.Payload["req.id"], "abc")
+	}
+}
+
+func TestSlogHandlerEnabledHonorsGlobalLevel(t *testing.T) {
+	withCaptureConfig(t)
+	globalConfig.LogLevel = WARN
+
+	h := SlogHandler()
+	if h.Enabled(nil, slog.LevelInfo) {
+		t.Error("Enabled(Info) = true with global level WARN, want false")
+	}
+	if !h.Enabled(nil, slog.LevelError) {
+		t.Error("Enabled(Error) = false with global level WARN, want true")
+	}
+}
+
+func TestGrpcV2LevelMethods(t *testing.T) {
+	cw := withCaptureConfig(t)
+
+	g := GrpcV2()
+	g.Infof("dialing %s", "localhost")
+	if got := cw.last(); got.Level != INFO || got.Message != "dialing localhost" {
+		t.Errorf("Infof produced %+v", got)
+	}
+
+	g.Warningln("retrying")
+	if got := cw.last(); got.Level != WARN {
+		t.Errorf("Warningln level = %q, want %q", got.Level, WARN)
+	}
+
+	g.Error("boom")
+	if got := cw.last(); got.Level != ERROR || got.Message != "boom" {
+		t.Errorf("Error produced %+v", got)
+	}
+}
+
+func TestLogrSinkInfoAndError(t *testing.T) {
+	cw := withCaptureConfig(t)
+
+	sink := LogrSink()
+	log := logr.New(sink).WithName("svc").WithValues("req_id", "42")
+
+	log.Info("starting up")
+	got := cw.last()
+	if got.Level != INFO {
+		t.Errorf("Level = %q, want %q", got.Level, INFO)
+	}
+	if got.Payload["req_id"] != "42" {
+		t.Errorf("Payload[req_id] = %v, want %q", got.Payload["req_id"], "42")
+	}
+	if got.Payload["logger"] != "svc" {
+		t.Errorf("Payload[logger] = %v, want %q", got.Payload["logger"], "svc")
+	}
+
+	log.Error(errors.New("disk full"), "write failed")
+	got = cw.last()
+	if got.Level != ERROR {
+		t.Errorf("Level = %q, want %q", got.Level, ERROR)
+	}
+	if got.Error != "disk full" {
+		t.Errorf("Error = %q, want %q", got.Error, "disk full")
+	}
+}
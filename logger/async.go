@@ -0,0 +1,193 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// OverflowPolicy controls what happens when the async queue is full.
+type OverflowPolicy int
+
+const (
+	// Block makes the caller wait for room in the queue.
+	Block OverflowPolicy = iota
+	// DropNewest discards the entry being enqueued.
+	DropNewest
+	// DropOldest evicts the oldest queued entry to make room.
+	DropOldest
+)
+
+// AsyncConfig configures the non-blocking async logging pipeline.
+type AsyncConfig struct {
+	Enabled         bool
+	BufferSize      int // channel capacity; defaults to 100 if <= 0
+	OverflowPolicy  OverflowPolicy
+	FlushInterval   time.Duration // periodic Flush() of writers that support it; 0 disables
+	ShutdownTimeout time.Duration // max time CloseLogger waits for drain if ctx has no deadline
+	FlushOnSignal   bool          // install a SIGTERM/SIGINT handler that calls CloseLogger
+}
+
+var (
+	logChannel     chan LogEntry
+	dispatcherDone chan struct{}
+	asyncDropped   int64
+
+	// closeOnce guards CloseLogger so a SIGTERM/SIGINT delivered while
+	// something else (e.g. Fatal) is already shutting down doesn't race a
+	// second close(logChannel) into a panic. It's reset whenever a new
+	// pipeline is started.
+	closeOnce   sync.Once
+	closeResult struct {
+		dropped, undrained int
+	}
+)
+
+// startAsyncPipeline allocates the queue and starts the dispatcher
+// goroutine. Called from Initialize when Config.Async.Enabled is set.
+func startAsyncPipeline() {
+	bufferSize := globalConfig.Async.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = 100
+	}
+	logChannel = make(chan LogEntry, bufferSize)
+	dispatcherDone = make(chan struct{})
+	atomic.StoreInt64(&asyncDropped, 0)
+	closeOnce = sync.Once{}
+	closeResult = struct{ dropped, undrained int }{}
+
+	go dispatchLogQueue()
+
+	if globalConfig.Async.FlushOnSignal {
+		go flushOnSignal()
+	}
+}
+
+// enqueueAsync hands entry to the async queue, applying the configured
+// overflow policy if the queue is full.
+func enqueueAsync(entry LogEntry) {
+	switch globalConfig.Async.OverflowPolicy {
+	case DropNewest:
+		select {
+		case logChannel <- entry:
+		default:
+			atomic.AddInt64(&asyncDropped, 1)
+		}
+	case DropOldest:
+		select {
+		case logChannel <- entry:
+		default:
+			select {
+			case <-logChannel:
+				atomic.AddInt64(&asyncDropped, 1)
+			default:
+			}
+			select {
+			case logChannel <- entry:
+			default:
+				atomic.AddInt64(&asyncDropped, 1)
+			}
+		}
+	default: // Block
+		logChannel <- entry
+	}
+}
+
+// dispatchLogQueue is the single goroutine draining logChannel. It writes
+// each entry as it arrives and periodically flushes any writer
+// implementing Flusher, so buffered writers don't hold entries
+// indefinitely between log calls.
+func dispatchLogQueue() {
+	defer close(dispatcherDone)
+
+	var tick <-chan time.Time
+	if globalConfig.Async.FlushInterval > 0 {
+		ticker := time.NewTicker(globalConfig.Async.FlushInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case entry, ok := <-logChannel:
+			if !ok {
+				flushWriters()
+				return
+			}
+			writeLog(entry)
+		case <-tick:
+			flushWriters()
+		}
+	}
+}
+
+// flushWriters calls Flush on every configured writer that implements
+// Flusher.
+func flushWriters() {
+	for _, w := range globalConfig.Writers {
+		if f, ok := w.(Flusher); ok {
+			if err := f.Flush(); err != nil {
+				fmt.Println("Failed to flush writer:", err)
+			}
+		}
+	}
+}
+
+// flushOnSignal waits for SIGTERM/SIGINT and drains the logger so buffered
+// entries aren't lost on shutdown.
+func flushOnSignal() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
+	<-sigCh
+	CloseLogger(context.Background())
+}
+
+// CloseLogger drains the async pipeline (if enabled) and closes every
+// configured writer. It waits for the dispatcher to finish draining until
+// ctx is done or, if ctx has no deadline, until Config.Async.ShutdownTimeout
+// elapses. It returns the number of entries dropped by the overflow policy
+// and the number still sitting in the queue when the wait gave up.
+//
+// CloseLogger is safe to call more than once, including concurrently: only
+// the first call actually drains and closes anything, and every call
+// (including the ones that raced or arrived late) returns that first
+// call's result. This matters because Fatal already calls CloseLogger, and
+// an orchestrator can deliver SIGTERM (handled via FlushOnSignal) to a
+// process that's concurrently failing and calling Fatal.
+func CloseLogger(ctx context.Context) (dropped, undrained int) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	closeOnce.Do(func() {
+		if globalConfig.Async.Enabled {
+			if _, hasDeadline := ctx.Deadline(); !hasDeadline && globalConfig.Async.ShutdownTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, globalConfig.Async.ShutdownTimeout)
+				defer cancel()
+			}
+
+			close(logChannel)
+			select {
+			case <-dispatcherDone:
+			case <-ctx.Done():
+			}
+
+			closeResult.undrained = len(logChannel)
+			closeResult.dropped = int(atomic.SwapInt64(&asyncDropped, 0))
+		}
+
+		for _, w := range globalConfig.Writers {
+			if err := w.Close(); err != nil {
+				fmt.Println("Failed to close writer:", err)
+			}
+		}
+	})
+
+	return closeResult.dropped, closeResult.undrained
+}
@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestCloseLoggerIsIdempotent guards against a "close of closed channel"
+// panic when CloseLogger is called twice, sequentially or concurrently
+// (e.g. Fatal() racing a FlushOnSignal-triggered shutdown).
+func TestCloseLoggerIsIdempotent(t *testing.T) {
+	prev := globalConfig
+	t.Cleanup(func() { globalConfig = prev })
+
+	globalConfig = Config{
+		Writers: []Writer{&captureWriter{}},
+		Async: AsyncConfig{
+			Enabled:         true,
+			BufferSize:      8,
+			ShutdownTimeout: time.Second,
+		},
+	}
+	startAsyncPipeline()
+
+	// Sequential double close must not panic.
+	CloseLogger(context.Background())
+	CloseLogger(context.Background())
+
+	// Concurrent close from multiple goroutines must not panic and must
+	// agree on the result.
+	globalConfig = Config{
+		Writers: []Writer{&captureWriter{}},
+		Async: AsyncConfig{
+			Enabled:         true,
+			BufferSize:      8,
+			ShutdownTimeout: time.Second,
+		},
+	}
+	startAsyncPipeline()
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			dropped, _ := CloseLogger(context.Background())
+			results[i] = dropped
+		}(i)
+	}
+	wg.Wait()
+
+	for i, r := range results {
+		if r != results[0] {
+			t.Errorf("result[%d] = %d, want %d (all callers should see the same result)", i, r, results[0])
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"context"
+	"os"
+)
+
+// Logger carries a set of Fields that are attached to every entry it logs.
+// Use With to derive a child Logger that layers additional fields on top
+// of its parent's, without mutating the parent.
+type Logger struct {
+	fields []Field
+}
+
+// DefaultLogger is the Logger backing the package-level Info/Debug/Warn/
+// Error/Fatal functions.
+var DefaultLogger = &Logger{}
+
+// New returns a Logger with no accumulated fields.
+func New() *Logger {
+	return &Logger{}
+}
+
+// With returns a child Logger carrying l's fields plus the given ones.
+// Fields added later take precedence over earlier ones with the same key
+// when merged into a payload.
+func (l *Logger) With(fields ...Field) *Logger {
+	merged := make([]Field, 0, len(l.fields)+len(fields))
+	merged = append(merged, l.fields...)
+	merged = append(merged, fields...)
+	return &Logger{fields: merged}
+}
+
+func (l *Logger) Debug(msg string, fields ...Field) {
+	l.log(DEBUG, msg, fields)
+}
+
+func (l *Logger) Info(msg string, fields ...Field) {
+	l.log(INFO, msg, fields)
+}
+
+func (l *Logger) Warn(msg string, fields ...Field) {
+	l.log(WARN, msg, fields)
+}
+
+func (l *Logger) Error(msg string, fields ...Field) {
+	l.log(ERROR, msg, fields)
+}
+
+// Fatal logs at FATAL, flushes the logger, and exits the process.
+func (l *Logger) Fatal(msg string, fields ...Field) {
+	l.log(FATAL, msg, fields)
+	CloseLogger(context.Background())
+	os.Exit(1)
+}
+
+// log merges l's accumulated fields with the call-site fields, splits out
+// a reserved "error" field, and hands off to the package-level emitter.
+func (l *Logger) log(level, msg string, callSiteFields []Field) {
+	payload, err := buildPayload(l.fields, callSiteFields)
+	log(level, msg, payload, err)
+}
+
+// emitMap is used by the package-level free functions to preserve their
+// existing map[string]interface{} signature while still picking up any
+// fields accumulated on l (DefaultLogger has none, so this is normally a
+// pass-through).
+func (l *Logger) emitMap(level, msg string, payload map[string]interface{}, err error) {
+	if len(l.fields) == 0 {
+		log(level, msg, payload, err)
+		return
+	}
+	merged := make(map[string]interface{}, len(payload)+len(l.fields))
+	for _, f := range l.fields {
+		merged[f.Key] = f.Value
+	}
+	for k, v := range payload {
+		merged[k] = v
+	}
+	log(level, msg, merged, err)
+}
+
+// buildPayload flattens parent and call-site fields into a payload map,
+// pulling out a reserved "error" field (set via Err) for LogEntry.Error
+// rather than folding it into Payload.
+func buildPayload(parent, callSite []Field) (map[string]interface{}, error) {
+	if len(parent) == 0 && len(callSite) == 0 {
+		return nil, nil
+	}
+
+	payload := make(map[string]interface{}, len(parent)+len(callSite))
+	var err error
+	apply := func(f Field) {
+		if f.Key == "error" {
+			if e, ok := f.Value.(error); ok {
+				err = e
+				return
+			}
+		}
+		payload[f.Key] = f.Value
+	}
+	for _, f := range parent {
+		apply(f)
+	}
+	for _, f := range callSite {
+		apply(f)
+	}
+	if len(payload) == 0 {
+		return nil, err
+	}
+	return payload, err
+}
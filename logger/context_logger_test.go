@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// captureWriter records every entry handed to it, for assertions.
+type captureWriter struct {
+	mu      sync.Mutex
+	entries []LogEntry
+}
+
+func (c *captureWriter) Write(entry LogEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, entry)
+	return nil
+}
+
+func (c *captureWriter) Close() error { return nil }
+
+func (c *captureWriter) last() LogEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[len(c.entries)-1]
+}
+
+func withCaptureWriter(t *testing.T) *captureWriter {
+	t.Helper()
+	cap := &captureWriter{}
+	prev := globalConfig
+	globalConfig = Config{Writers: []Writer{cap}, LogLevel: DEBUG}
+	t.Cleanup(func() { globalConfig = prev })
+	return cap
+}
+
+// TestFreeFunctionReportsCallSite guards against the caller-skip regression
+// where Info/Debug/Warn/Error routing through DefaultLogger.emitMap made
+// every LogEntry report a location inside the logger package itself
+// instead of the real call site.
+func TestFreeFunctionReportsCallSite(t *testing.T) {
+	cap := withCaptureWriter(t)
+
+	_, wantFile, callLine, ok := runtime.Caller(0)
+	Info("hello", nil)
+	wantLine := callLine + 1
+	if !ok {
+		t.Fatal("runtime.Caller(0) failed")
+	}
+
+	entry := cap.last()
+	if !strings.HasSuffix(wantFile, entry.File) {
+		t.Errorf("File = %q, want suffix of %q", entry.File, wantFile)
+	}
+	if entry.Line != wantLine {
+		t.Errorf("Line = %d, want %d", entry.Line, wantLine)
+	}
+}
+
+// TestLoggerMethodReportsCallSite is the Logger-type counterpart: calls
+// through l.log must also attribute to the real caller, not to
+// context_logger.go itself.
+func TestLoggerMethodReportsCallSite(t *testing.T) {
+	cap := withCaptureWriter(t)
+	l := New()
+
+	_, wantFile, callLine, ok := runtime.Caller(0)
+	l.Info("hello")
+	wantLine := callLine + 1
+	if !ok {
+		t.Fatal("runtime.Caller(0) failed")
+	}
+
+	entry := cap.last()
+	if !strings.HasSuffix(wantFile, entry.File) {
+		t.Errorf("File = %q, want suffix of %q", entry.File, wantFile)
+	}
+	if entry.Line != wantLine {
+		t.Errorf("Line = %d, want %d", entry.Line, wantLine)
+	}
+}
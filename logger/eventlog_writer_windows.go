@@ -0,0 +1,48 @@
+//go:build windows
+
+package logger
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// WindowsEventLogWriter sends entries to the Windows Event Log under the
+// given source name. The source must already be registered (e.g. via
+// eventlog.InstallAsEventCreate) before entries can be written.
+type WindowsEventLogWriter struct {
+	log *eventlog.Log
+}
+
+// NewWindowsEventLogWriter opens the named event source.
+func NewWindowsEventLogWriter(source string) (*WindowsEventLogWriter, error) {
+	log, err := eventlog.Open(source)
+	if err != nil {
+		return nil, fmt.Errorf("eventlog: open %s: %w", source, err)
+	}
+	return &WindowsEventLogWriter{log: log}, nil
+}
+
+func (w *WindowsEventLogWriter) Write(entry LogEntry) error {
+	msg := entry.Message
+	if entry.Error != "" {
+		msg = fmt.Sprintf("%s\nError: %s", msg, entry.Error)
+	}
+	if entry.Payload != nil {
+		msg = fmt.Sprintf("%s\nPayload: %+v", msg, entry.Payload)
+	}
+
+	switch entry.Level {
+	case ERROR, FATAL:
+		return w.log.Error(1, msg)
+	case WARN:
+		return w.log.Warning(2, msg)
+	default:
+		return w.log.Info(3, msg)
+	}
+}
+
+func (w *WindowsEventLogWriter) Close() error {
+	return w.log.Close()
+}
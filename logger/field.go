@@ -0,0 +1,39 @@
+package logger
+
+import "time"
+
+// Field is a single piece of structured context attached to a log call or
+// accumulated on a child Logger via With. Using a typed constructor instead
+// of a bare map[string]interface{} avoids a map allocation per call site.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// String builds a string-valued Field.
+func String(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int builds an int-valued Field.
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Duration builds a Field whose value is a time.Duration.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Any builds a Field from an arbitrary value, for types without a
+// dedicated constructor.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Err builds a Field carrying an error under the reserved "error" key.
+// Loggers recognize this key and populate LogEntry.Error instead of
+// folding it into Payload.
+func Err(err error) Field {
+	return Field{Key: "error", Value: err}
+}
@@ -0,0 +1,166 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countBackups returns the number of rotated segments (compressed or not)
+// for path in its directory.
+func countBackups(t *testing.T, path string) int {
+	t.Helper()
+	dir := filepath.Dir(path)
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(filepath.Base(path), ext)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+
+	n := 0
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, base+"-") && (strings.HasSuffix(name, ext) || strings.HasSuffix(name, ext+".gz")) {
+			n++
+		}
+	}
+	return n
+}
+
+// TestFileWriterRotateRespectsMaxBackups reproduces the originally-reported
+// race: bursty MaxSize-triggered rotations firing independent
+// compress/prune goroutines per rotation left the backup count
+// non-deterministic instead of capped at MaxBackups. With compress+prune
+// now serialized under w.mu, exactly MaxBackups segments must remain.
+func TestFileWriterRotateRespectsMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w, err := NewFileWriter(path, false)
+	if err != nil {
+		t.Fatalf("NewFileWriter: %v", err)
+	}
+	w.MaxSize = 50
+	w.MaxBackups = 2
+	w.Compress = true
+	defer w.Close()
+
+	entry := LogEntry{Timestamp: "2024-01-01T00:00:00Z", Level: INFO, File: "x.go", Line: 1, Function: "f", Message: strings.Repeat("x", 40)}
+	for i := 0; i < 30; i++ {
+		if err := w.Write(entry); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	if got := countBackups(t, path); got != w.MaxBackups {
+		t.Errorf("backups after burst = %d, want %d", got, w.MaxBackups)
+	}
+}
+
+// TestFileWriterCompressGzipsRotatedSegment checks that a rotated segment is
+// gzip-compressed and the plain-text source is removed.
+func TestFileWriterCompressGzipsRotatedSegment(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w, err := NewFileWriter(path, false)
+	if err != nil {
+		t.Fatalf("NewFileWriter: %v", err)
+	}
+	w.MaxSize = 10
+	w.Compress = true
+	defer w.Close()
+
+	entry := LogEntry{Timestamp: "2024-01-01T00:00:00Z", Level: INFO, File: "x.go", Line: 1, Function: "f", Message: "hello world, this is long enough to rotate"}
+	if err := w.Write(entry); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Write(entry); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+
+	var gz string
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".gz") {
+			gz = filepath.Join(dir, e.Name())
+		}
+		if strings.HasSuffix(e.Name(), ".log") && e.Name() != "app.log" {
+			t.Errorf("found uncompressed rotated segment %s, want it removed after compress", e.Name())
+		}
+	}
+	if gz == "" {
+		t.Fatal("no .gz backup found after rotation with Compress=true")
+	}
+
+	f, err := os.Open(gz)
+	if err != nil {
+		t.Fatalf("open %s: %v", gz, err)
+	}
+	defer f.Close()
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	defer gzr.Close()
+	data, err := io.ReadAll(gzr)
+	if err != nil {
+		t.Fatalf("read gzip contents: %v", err)
+	}
+	if !strings.Contains(string(data), "hello world") {
+		t.Errorf("decompressed backup = %q, want it to contain the rotated entry", data)
+	}
+}
+
+// TestFileWriterPruneRemovesOldestFirst checks that prune evicts the
+// least-recently-modified backups once MaxBackups is exceeded.
+func TestFileWriterPruneRemovesOldestFirst(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w, err := NewFileWriter(path, false)
+	if err != nil {
+		t.Fatalf("NewFileWriter: %v", err)
+	}
+	w.MaxSize = 20
+	w.MaxBackups = 1
+	defer w.Close()
+
+	entry := LogEntry{Timestamp: "2024-01-01T00:00:00Z", Level: INFO, File: "x.go", Line: 1, Function: "f", Message: "this message is long enough to force rotation"}
+	var lastRotation time.Time
+	for i := 0; i < 3; i++ {
+		if i == 2 {
+			lastRotation = time.Now().UTC()
+		}
+		if err := w.Write(entry); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	if got := countBackups(t, path); got != 1 {
+		t.Errorf("backups = %d, want 1", got)
+	}
+
+	// Every write rotates, so the surviving backup must be the one made by
+	// the last write; backup names embed the rotation time.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	cutoff := "app-" + lastRotation.Format("20060102T150405.000000000") + ".log"
+	for _, e := range entries {
+		if e.Name() != "app.log" && e.Name() < cutoff {
+			t.Errorf("older backup %s kept, want only the newest one", e.Name())
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/grpclog"
+)
+
+// grpcLoggerV2 adapts a Logger to grpclog.LoggerV2 so gRPC's internal
+// logging lands in this package's pipeline.
+type grpcLoggerV2 struct {
+	logger *Logger
+}
+
+// GrpcV2 returns a grpclog.LoggerV2 backed by DefaultLogger. Install it
+// with grpclog.SetLoggerV2.
+func GrpcV2() grpclog.LoggerV2 {
+	return &grpcLoggerV2{logger: DefaultLogger}
+}
+
+func (g *grpcLoggerV2) Info(args ...interface{})   { g.logger.Info(fmt.Sprint(args...)) }
+func (g *grpcLoggerV2) Infoln(args ...interface{}) { g.logger.Info(fmt.Sprintln(args...)) }
+func (g *grpcLoggerV2) Infof(format string, args ...interface{}) {
+	g.logger.Info(fmt.Sprintf(format, args...))
+}
+func (g *grpcLoggerV2) Warning(args ...interface{})   { g.logger.Warn(fmt.Sprint(args...)) }
+func (g *grpcLoggerV2) Warningln(args ...interface{}) { g.logger.Warn(fmt.Sprintln(args...)) }
+func (g *grpcLoggerV2) Warningf(format string, args ...interface{}) {
+	g.logger.Warn(fmt.Sprintf(format, args...))
+}
+func (g *grpcLoggerV2) Error(args ...interface{})   { g.logger.Error(fmt.Sprint(args...)) }
+func (g *grpcLoggerV2) Errorln(args ...interface{}) { g.logger.Error(fmt.Sprintln(args...)) }
+func (g *grpcLoggerV2) Errorf(format string, args ...interface{}) {
+	g.logger.Error(fmt.Sprintf(format, args...))
+}
+
+// Fatal, Fatalln, and Fatalf must terminate the process per the
+// grpclog.LoggerV2 contract.
+func (g *grpcLoggerV2) Fatal(args ...interface{})   { g.logger.Fatal(fmt.Sprint(args...)) }
+func (g *grpcLoggerV2) Fatalln(args ...interface{}) { g.logger.Fatal(fmt.Sprintln(args...)) }
+func (g *grpcLoggerV2) Fatalf(format string, args ...interface{}) {
+	g.logger.Fatal(fmt.Sprintf(format, args...))
+}
+
+func (g *grpcLoggerV2) V(l int) bool {
+	return V(l)
+}
@@ -0,0 +1,116 @@
+//go:build linux
+
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+)
+
+const journaldSocket = "/run/systemd/journal/socket"
+
+var journalPriority = map[string]int{
+	DEBUG: 7,
+	INFO:  6,
+	WARN:  4,
+	ERROR: 3,
+	FATAL: 2,
+}
+
+// JournaldWriter sends entries to the native systemd-journald datagram
+// socket using the KEY=value wire protocol described in systemd's
+// sd_journal_sendv(3) man page.
+type JournaldWriter struct {
+	conn net.Conn
+}
+
+// NewJournaldWriter connects to the local journald socket.
+func NewJournaldWriter() (*JournaldWriter, error) {
+	conn, err := net.Dial("unixgram", journaldSocket)
+	if err != nil {
+		return nil, fmt.Errorf("journald: dial %s: %w", journaldSocket, err)
+	}
+	return &JournaldWriter{conn: conn}, nil
+}
+
+func (w *JournaldWriter) Write(entry LogEntry) error {
+	var buf bytes.Buffer
+	writeJournaldField(&buf, "PRIORITY", fmt.Sprintf("%d", journalPriority[entry.Level]))
+	writeJournaldField(&buf, "MESSAGE", entry.Message)
+	writeJournaldField(&buf, "SYSLOG_IDENTIFIER", entry.Function)
+	writeJournaldField(&buf, "CODE_FILE", entry.File)
+	writeJournaldField(&buf, "CODE_LINE", fmt.Sprintf("%d", entry.Line))
+	writeJournaldField(&buf, "CODE_FUNC", entry.Function)
+	writeJournaldField(&buf, "PID", fmt.Sprintf("%d", entry.ProcessID))
+	if entry.Error != "" {
+		writeJournaldField(&buf, "ERROR", entry.Error)
+	}
+	for k, v := range entry.Payload {
+		writeJournaldField(&buf, journaldFieldName(k), fmt.Sprintf("%v", v))
+	}
+
+	_, err := w.conn.Write(buf.Bytes())
+	if err != nil && isMessageTooLong(err) {
+		return w.writeViaMemfd(buf.Bytes())
+	}
+	return err
+}
+
+// writeJournaldField appends a KEY=value line, falling back to the
+// binary-safe "KEY\n<uint64le len>value\n" framing when value contains a
+// newline (which would otherwise be ambiguous with the text framing).
+func writeJournaldField(buf *bytes.Buffer, key, value string) {
+	if !strings.Contains(value, "\n") {
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteString(key)
+	buf.WriteByte('\n')
+	var lenBuf [8]byte
+	binary.LittleEndian.PutUint64(lenBuf[:], uint64(len(value)))
+	buf.Write(lenBuf[:])
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journaldFieldName converts an arbitrary payload key into a valid journald
+// field name: uppercase ASCII letters, digits, and underscores, with any
+// other character replaced by '_' and a leading '_' added if the key would
+// otherwise start with a digit (journald field names may not start with one).
+func journaldFieldName(key string) string {
+	var b strings.Builder
+	if len(key) > 0 && key[0] >= '0' && key[0] <= '9' {
+		b.WriteByte('_')
+	}
+	for _, r := range strings.ToUpper(key) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// isMessageTooLong reports whether err indicates the datagram exceeded the
+// socket's size limit, in which case journald expects the payload to be
+// passed via a sealed memfd instead. Large structured payloads are rare for
+// this SDK, so that path is not implemented; Write returns a "payload exceeds
+// datagram size limit" error instead.
+func isMessageTooLong(err error) bool {
+	return strings.Contains(err.Error(), "message too long")
+}
+
+func (w *JournaldWriter) writeViaMemfd(_ []byte) error {
+	return fmt.Errorf("journald: payload exceeds datagram size limit")
+}
+
+func (w *JournaldWriter) Close() error {
+	return w.conn.Close()
+}
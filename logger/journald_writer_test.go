@@ -0,0 +1,74 @@
+//go:build linux
+
+package logger
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestJournaldWriterEmitsKeyValueFieldsIncludingPayload(t *testing.T) {
+	dir := t.TempDir()
+	sockPath := dir + "/journal.sock"
+
+	listener, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: sockPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("ListenUnixgram: %v", err)
+	}
+	defer listener.Close()
+
+	conn, err := net.Dial("unixgram", sockPath)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	w := &JournaldWriter{conn: conn}
+	defer w.Close()
+
+	entry := LogEntry{
+		Level:     WARN,
+		Message:   "cache miss",
+		File:      "cache/store.go",
+		Line:      42,
+		Function:  "cache.Get",
+		ProcessID: 99,
+		Payload:   map[string]interface{}{"key": "user:123"},
+	}
+	if err := w.Write(entry); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	n, err := listener.Read(buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	msg := string(buf[:n])
+
+	for _, want := range []string{
+		"PRIORITY=4", // WARN
+		"MESSAGE=cache miss",
+		"CODE_FILE=cache/store.go",
+		"CODE_LINE=42",
+		"PID=99",
+		"KEY=user:123",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("journald datagram = %q, want it to contain %q", msg, want)
+		}
+	}
+}
+
+func TestJournaldFieldNameSanitizesKeys(t *testing.T) {
+	cases := map[string]string{
+		"key":        "KEY",
+		"user-id":    "USER_ID",
+		"1st_field":  "_1ST_FIELD",
+		"Already_OK": "ALREADY_OK",
+	}
+	for in, want := range cases {
+		if got := journaldFieldName(in); got != want {
+			t.Errorf("journaldFieldName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -1,12 +1,12 @@
 package logger
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"runtime"
 	"strings"
-	"sync"
 	"time"
 )
 
@@ -21,23 +21,17 @@ const (
 
 // Config defines the logger configuration
 type Config struct {
-	Mode        string // "console" or "file"
-	FilePath    string // Path for log file (if Mode is "file")
-	JSONFormat  bool   // If true, use JSON log format
-	LogLevel    string // Minimum log level to log
-	EnableAsync bool   // Enable asynchronous logging
+	Writers  []Writer    // Sinks entries are fanned out to
+	LogLevel string      // Minimum log level to log
+	Async    AsyncConfig // Async.Enabled turns on the async pipeline
+	Sampling Sampler     // Optional rate limiter/sampler; nil disables sampling
 }
 
 var globalConfig = Config{
-	Mode:        "console",
-	JSONFormat:  false,
-	LogLevel:    DEBUG,
-	EnableAsync: false,
+	Writers:  []Writer{NewConsoleWriter(os.Stdout, false)},
+	LogLevel: DEBUG,
 }
 
-var logChannel chan LogEntry
-var wg sync.WaitGroup
-
 // LogEntry defines the structure of a log entry
 type LogEntry struct {
 	Timestamp string                 `json:"timestamp"`
@@ -54,17 +48,9 @@ type LogEntry struct {
 // Initialize sets the global logger configuration
 func Initialize(config Config) {
 	globalConfig = config
-	if globalConfig.EnableAsync {
-		logChannel = make(chan LogEntry, 100)
-		go processLogQueue()
-	}
-}
-
-// processLogQueue processes log entries asynchronously
-func processLogQueue() {
-	for entry := range logChannel {
-		writeLog(entry)
-		wg.Done()
+	vCache.purge() // V results depend on LogLevel
+	if globalConfig.Async.Enabled {
+		startAsyncPipeline()
 	}
 }
 
@@ -82,27 +68,43 @@ func getCallerInfo(skip int) (file string, line int, funcName string) {
 	return file, line, funcName
 }
 
-// trimFilePath trims the file path to show only the file name
+// trimFilePath trims the file path to the package directory and file name
+// (e.g. "auth/service.go"), which is enough context for vmodule matching
+// while staying short in log output.
 func trimFilePath(fullPath string) string {
 	parts := strings.Split(fullPath, "/")
-	return parts[len(parts)-1]
+	if len(parts) < 2 {
+		return parts[len(parts)-1]
+	}
+	return strings.Join(parts[len(parts)-2:], "/")
 }
 
-// isLogLevelEnabled checks if the current log level is enabled
-func isLogLevelEnabled(level string) bool {
-	levels := map[string]int{
-		DEBUG: 1, INFO: 2, WARN: 3, ERROR: 4, FATAL: 5,
+// levelRanks orders levels by severity for threshold comparisons.
+var levelRanks = map[string]int{
+	DEBUG: 1, INFO: 2, WARN: 3, ERROR: 4, FATAL: 5,
+}
+
+// isLogLevelEnabled checks if level is enabled for file, honoring any
+// vmodule override that matches file before falling back to the global
+// LogLevel.
+func isLogLevelEnabled(level, file string) bool {
+	if rank, ok := matchVModule(file); ok {
+		return levelRanks[level] >= rank
 	}
-	return levels[level] >= levels[globalConfig.LogLevel]
+	return levelRanks[level] >= levelRanks[globalConfig.LogLevel]
 }
 
 // log constructs and logs the entry based on the global configuration
 func log(level, msg string, payload map[string]interface{}, err error) {
-	if !isLogLevelEnabled(level) {
+	// Every call path here goes through Logger.log or Logger.emitMap (the
+	// free functions and Logger's own methods both route through one of
+	// those), which adds one extra frame versus the pre-Logger direct call
+	// from Info/Debug/etc., hence skip=4 rather than 3.
+	file, line, funcName := getCallerInfo(4)
+	if !isLogLevelEnabled(level, file) {
 		return
 	}
 
-	file, line, funcName := getCallerInfo(3) // Adjust skip for logger calls
 	entry := LogEntry{
 		Timestamp: time.Now().Format(time.RFC3339),
 		Level:     level,
@@ -119,100 +121,95 @@ func log(level, msg string, payload map[string]interface{}, err error) {
 		entry.Error = err.Error()
 	}
 
-	if globalConfig.EnableAsync {
-		wg.Add(1)
-		logChannel <- entry
+	if globalConfig.Sampling != nil {
+		allowed, dropped := globalConfig.Sampling.Allow(level, msg)
+		if !allowed {
+			return
+		}
+		if dropped > 0 {
+			if entry.Payload == nil {
+				entry.Payload = make(map[string]interface{})
+			}
+			entry.Payload["sampled_dropped"] = dropped
+		}
+	}
+
+	if globalConfig.Async.Enabled {
+		enqueueAsync(entry)
 	} else {
 		writeLog(entry)
 	}
 }
 
-// writeLog writes the log entry based on the global configuration
+// writeLog fans the entry out to every configured writer.
 func writeLog(entry LogEntry) {
-	if globalConfig.Mode == "console" {
-		outputConsole(entry)
-	} else if globalConfig.Mode == "file" && globalConfig.FilePath != "" {
-		outputFile(entry)
-	}
-}
-
-// outputConsole prints the log entry to the console
-func outputConsole(entry LogEntry) {
-	if globalConfig.JSONFormat {
-		data, _ := json.Marshal(entry)
-		fmt.Println(string(data))
-	} else {
-		fmt.Printf("[%s] %s %s:%d %s - %s\n",
-			entry.Timestamp, entry.Level, entry.File, entry.Line, entry.Function, entry.Message)
-		if entry.Error != "" {
-			fmt.Printf("Error: %s\n", entry.Error)
-		}
-		if entry.Payload != nil {
-			fmt.Printf("Payload: %+v\n", entry.Payload)
+	for _, w := range globalConfig.Writers {
+		if err := w.Write(entry); err != nil {
+			fmt.Println("Failed to write log:", err)
 		}
 	}
 }
 
-// outputFile writes the log entry to a file
-func outputFile(entry LogEntry) {
-	var data string
-	if globalConfig.JSONFormat {
-		jsonData, _ := json.Marshal(entry)
-		data = string(jsonData)
-	} else {
-		data = fmt.Sprintf("[%s] %s %s:%d %s - %s",
-			entry.Timestamp, entry.Level, entry.File, entry.Line, entry.Function, entry.Message)
-		if entry.Error != "" {
-			data += fmt.Sprintf("\nError: %s", entry.Error)
+// formatEntry renders entry as either a JSON line or the default
+// "[timestamp] LEVEL file:line func - message" text line, with an
+// "Error: ..." line and a "Payload: ..." line appended when the entry
+// carries them. It's shared by writers that don't need anything fancier
+// than plain text or JSON.
+func formatEntry(entry LogEntry, jsonFormat bool) (string, error) {
+	if jsonFormat {
+		data, err := marshalEntry(entry)
+		if err != nil {
+			return "", err
 		}
+		return string(data), nil
 	}
 
-	f, err := os.OpenFile(globalConfig.FilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		fmt.Println("Failed to write log:", err)
-		return
+	data := fmt.Sprintf("[%s] %s %s:%d %s - %s",
+		entry.Timestamp, entry.Level, entry.File, entry.Line, entry.Function, entry.Message)
+	if entry.Error != "" {
+		data += fmt.Sprintf("\nError: %s", entry.Error)
 	}
-	defer f.Close()
-
-	if _, err := f.WriteString(data + "\n"); err != nil {
-		fmt.Println("Failed to write log data:", err)
+	if entry.Payload != nil {
+		data += fmt.Sprintf("\nPayload: %+v", entry.Payload)
 	}
+	return data, nil
 }
 
-// CloseLogger waits for all logs to be processed and closes the log channel
-func CloseLogger() {
-	if globalConfig.EnableAsync {
-		wg.Wait()
-		close(logChannel)
-	}
+// marshalEntry JSON-encodes entry.
+func marshalEntry(entry LogEntry) ([]byte, error) {
+	return json.Marshal(entry)
 }
 
 // Public logging functions
+//
+// These are thin wrappers over DefaultLogger kept for backwards
+// compatibility; new call sites may prefer DefaultLogger.With(...) or a
+// Logger obtained from New() for typed, reusable context fields.
 
 // Info logs an informational message
 func Info(msg string, payload map[string]interface{}) {
-	log(INFO, msg, payload, nil)
+	DefaultLogger.emitMap(INFO, msg, payload, nil)
 }
 
 // Debug logs a debug message
 func Debug(msg string, payload map[string]interface{}) {
-	log(DEBUG, msg, payload, nil)
+	DefaultLogger.emitMap(DEBUG, msg, payload, nil)
 }
 
 // Warn logs a warning message
 func Warn(msg string, payload map[string]interface{}) {
-	log(WARN, msg, payload, nil)
+	DefaultLogger.emitMap(WARN, msg, payload, nil)
 }
 
 // Error logs an error message
 func Error(msg string, err error, payload map[string]interface{}) {
-	log(ERROR, msg, payload, err)
+	DefaultLogger.emitMap(ERROR, msg, payload, err)
 }
 
 // Fatal logs a fatal error and exits
 func Fatal(msg string, err error, payload map[string]interface{}) {
-	log(FATAL, msg, payload, err)
-	CloseLogger() // Ensure all async logs are processed before exiting
+	DefaultLogger.emitMap(FATAL, msg, payload, err)
+	CloseLogger(context.Background()) // Ensure all async logs are processed before exiting
 	os.Exit(1)
 }
 
@@ -0,0 +1,65 @@
+package logger
+
+import "github.com/go-logr/logr"
+
+// logrSink adapts a Logger to logr.LogSink so code written against logr
+// (client-go, controller-runtime, etc.) ends up in this package's pipeline.
+type logrSink struct {
+	logger *Logger
+	name   string
+}
+
+// LogrSink returns a logr.LogSink backed by DefaultLogger. Wrap it with
+// logr.New to get an logr.Logger.
+func LogrSink() logr.LogSink {
+	return &logrSink{logger: DefaultLogger}
+}
+
+func (s *logrSink) Init(info logr.RuntimeInfo) {}
+
+func (s *logrSink) Enabled(level int) bool {
+	if level == 0 {
+		return levelRanks[INFO] >= levelRanks[globalConfig.LogLevel]
+	}
+	return V(level)
+}
+
+func (s *logrSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.logger.Info(msg, s.fields(keysAndValues)...)
+}
+
+func (s *logrSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	fields := append(s.fields(keysAndValues), Err(err))
+	s.logger.Error(msg, fields...)
+}
+
+func (s *logrSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &logrSink{logger: s.logger.With(logrKVToFields(keysAndValues)...), name: s.name}
+}
+
+func (s *logrSink) WithName(name string) logr.LogSink {
+	full := name
+	if s.name != "" {
+		full = s.name + "." + name
+	}
+	return &logrSink{logger: s.logger, name: full}
+}
+
+// fields builds the field list for a single Info/Error call, tagging it
+// with the sink's name (logr's convention for the "logger" key) when set.
+func (s *logrSink) fields(keysAndValues []interface{}) []Field {
+	fields := logrKVToFields(keysAndValues)
+	if s.name != "" {
+		fields = append(fields, String("logger", s.name))
+	}
+	return fields
+}
+
+func logrKVToFields(keysAndValues []interface{}) []Field {
+	fields := make([]Field, 0, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, _ := keysAndValues[i].(string)
+		fields = append(fields, Any(key, keysAndValues[i+1]))
+	}
+	return fields
+}
@@ -0,0 +1,179 @@
+package logger
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// samplerKeyCapacity bounds how many distinct (level, msg) keys a sampler
+// retains state for. Without a bound, a caller logging unbounded-cardinality
+// messages (e.g. including a request ID in msg) would grow these maps
+// forever; evicting the least-recently-used key, as vmodule.go's
+// verbosityCache does, caps memory at the cost of occasionally resetting a
+// cold key's bucket/window.
+const samplerKeyCapacity = 4096
+
+// Sampler decides whether a log entry identified by (level, msg) should
+// pass through to the writers. It also reports how many prior occurrences
+// of the same key were suppressed since the last one that passed, so the
+// caller can surface that count on the entry that does get through.
+type Sampler interface {
+	Allow(level, msg string) (ok bool, dropped int)
+}
+
+// TokenBucketSampler rate-limits each distinct (level, msg) key to at most
+// Rate events per Interval, using a standard token bucket so bursts up to
+// Rate are allowed immediately and the rest trickle in as tokens refill.
+type TokenBucketSampler struct {
+	Rate     int
+	Interval time.Duration
+
+	mu      sync.Mutex
+	buckets map[string]*list.Element // key -> element wrapping *tokenBucketEntry
+	ll      *list.List               // front = most recently used
+}
+
+type tokenBucketEntry struct {
+	key   string
+	state tokenBucketState
+}
+
+type tokenBucketState struct {
+	tokens  float64
+	last    time.Time
+	dropped int
+}
+
+// NewTokenBucketSampler builds a sampler allowing up to rate events per
+// interval for each distinct (level, msg) key. State for at most
+// samplerKeyCapacity keys is retained; least-recently-used keys are evicted
+// beyond that.
+func NewTokenBucketSampler(rate int, interval time.Duration) *TokenBucketSampler {
+	return &TokenBucketSampler{
+		Rate:     rate,
+		Interval: interval,
+		buckets:  make(map[string]*list.Element),
+		ll:       list.New(),
+	}
+}
+
+func (s *TokenBucketSampler) Allow(level, msg string) (bool, int) {
+	key := level + "|" + msg
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.buckets[key]
+	var state *tokenBucketState
+	if !ok {
+		el = s.ll.PushFront(&tokenBucketEntry{key: key, state: tokenBucketState{tokens: float64(s.Rate), last: now}})
+		s.buckets[key] = el
+		state = &el.Value.(*tokenBucketEntry).state
+		if s.ll.Len() > samplerKeyCapacity {
+			oldest := s.ll.Back()
+			s.ll.Remove(oldest)
+			delete(s.buckets, oldest.Value.(*tokenBucketEntry).key)
+		}
+	} else {
+		s.ll.MoveToFront(el)
+		state = &el.Value.(*tokenBucketEntry).state
+		elapsed := now.Sub(state.last).Seconds()
+		refill := elapsed * (float64(s.Rate) / s.Interval.Seconds())
+		state.tokens += refill
+		if state.tokens > float64(s.Rate) {
+			state.tokens = float64(s.Rate)
+		}
+		state.last = now
+	}
+
+	if state.tokens >= 1 {
+		state.tokens--
+		dropped := state.dropped
+		state.dropped = 0
+		return true, dropped
+	}
+
+	state.dropped++
+	return false, 0
+}
+
+// TailSampler logs the first N occurrences of a repeated (level, msg) key
+// within each one-second window, then only every Mth occurrence after
+// that, so a log storm degrades to a steady trickle instead of silence.
+type TailSampler struct {
+	First      int
+	Thereafter int
+
+	mu    sync.Mutex
+	state map[string]*list.Element // key -> element wrapping *tailEntry
+	ll    *list.List               // front = most recently used
+}
+
+type tailEntry struct {
+	key   string
+	state tailState
+}
+
+type tailState struct {
+	windowStart time.Time
+	count       int
+	dropped     int
+}
+
+// NewTailSampler builds a sampler that passes the first `first` occurrences
+// of a key each second, then 1 in `thereafter` after that. State for at most
+// samplerKeyCapacity keys is retained; least-recently-used keys are evicted
+// beyond that.
+func NewTailSampler(first, thereafter int) *TailSampler {
+	return &TailSampler{
+		First:      first,
+		Thereafter: thereafter,
+		state:      make(map[string]*list.Element),
+		ll:         list.New(),
+	}
+}
+
+func (s *TailSampler) Allow(level, msg string) (bool, int) {
+	key := level + "|" + msg
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.state[key]
+	var st *tailState
+	if !ok {
+		el = s.ll.PushFront(&tailEntry{key: key, state: tailState{windowStart: now}})
+		s.state[key] = el
+		st = &el.Value.(*tailEntry).state
+		if s.ll.Len() > samplerKeyCapacity {
+			oldest := s.ll.Back()
+			s.ll.Remove(oldest)
+			delete(s.state, oldest.Value.(*tailEntry).key)
+		}
+	} else {
+		s.ll.MoveToFront(el)
+		st = &el.Value.(*tailEntry).state
+		if now.Sub(st.windowStart) >= time.Second {
+			*st = tailState{windowStart: now, dropped: st.dropped}
+		}
+	}
+
+	st.count++
+	if st.count <= s.First {
+		dropped := st.dropped
+		st.dropped = 0
+		return true, dropped
+	}
+
+	if s.Thereafter > 0 && (st.count-s.First)%s.Thereafter == 0 {
+		dropped := st.dropped
+		st.dropped = 0
+		return true, dropped
+	}
+
+	st.dropped++
+	return false, 0
+}
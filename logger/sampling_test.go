@@ -0,0 +1,137 @@
+package logger
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestTokenBucketSamplerAllowsBurstThenThrottles checks that a fresh key
+// gets Rate immediate passes, then is throttled until tokens refill, and
+// reports how many throttled calls were dropped.
+func TestTokenBucketSamplerAllowsBurstThenThrottles(t *testing.T) {
+	s := NewTokenBucketSampler(3, time.Second)
+
+	for i := 0; i < 3; i++ {
+		if ok, dropped := s.Allow(INFO, "msg"); !ok || dropped != 0 {
+			t.Fatalf("call %d: Allow = (%v, %d), want (true, 0)", i, ok, dropped)
+		}
+	}
+
+	ok, dropped := s.Allow(INFO, "msg")
+	if ok {
+		t.Fatalf("4th call within burst: Allow = true, want false (bucket exhausted)")
+	}
+	if dropped != 0 {
+		t.Fatalf("4th call dropped = %d, want 0 (nothing has passed yet to report it on)", dropped)
+	}
+
+	// Force a refill by rewinding last seen time instead of sleeping.
+	s.mu.Lock()
+	el := s.buckets[INFO+"|msg"]
+	el.Value.(*tokenBucketEntry).state.last = time.Now().Add(-time.Second)
+	s.mu.Unlock()
+
+	ok, dropped = s.Allow(INFO, "msg")
+	if !ok {
+		t.Fatalf("call after refill: Allow = false, want true")
+	}
+	if dropped != 1 {
+		t.Fatalf("call after refill dropped = %d, want 1 (the exhausted 4th call)", dropped)
+	}
+}
+
+// TestTokenBucketSamplerDistinctKeysIndependent checks that distinct
+// (level, msg) keys get independent buckets.
+func TestTokenBucketSamplerDistinctKeysIndependent(t *testing.T) {
+	s := NewTokenBucketSampler(1, time.Second)
+
+	if ok, _ := s.Allow(INFO, "a"); !ok {
+		t.Fatal("first call for key a: want true")
+	}
+	if ok, _ := s.Allow(INFO, "b"); !ok {
+		t.Fatal("first call for key b: want true (independent bucket from a)")
+	}
+	if ok, _ := s.Allow(INFO, "a"); ok {
+		t.Fatal("second call for key a: want false (bucket exhausted)")
+	}
+}
+
+// TestTokenBucketSamplerEvictsLRUBeyondCapacity checks that the bucket map
+// is bounded: pushing more distinct keys than samplerKeyCapacity evicts the
+// least-recently-used one instead of growing forever.
+func TestTokenBucketSamplerEvictsLRUBeyondCapacity(t *testing.T) {
+	s := NewTokenBucketSampler(1, time.Second)
+
+	s.Allow(INFO, "first")
+	for i := 0; i < samplerKeyCapacity; i++ {
+		s.Allow(INFO, fmt.Sprintf("filler-%d", i))
+	}
+
+	s.mu.Lock()
+	n := len(s.buckets)
+	_, stillPresent := s.buckets[INFO+"|first"]
+	s.mu.Unlock()
+
+	if n > samplerKeyCapacity {
+		t.Errorf("bucket count = %d, want <= %d", n, samplerKeyCapacity)
+	}
+	if stillPresent {
+		t.Error("oldest key \"first\" still present after exceeding capacity, want it evicted")
+	}
+}
+
+// TestTailSamplerPassesFirstNThenEveryMth checks the first-N-then-every-Mth
+// behavior within a single window.
+func TestTailSamplerPassesFirstNThenEveryMth(t *testing.T) {
+	s := NewTailSampler(2, 3)
+
+	var got []bool
+	for i := 0; i < 8; i++ {
+		ok, _ := s.Allow(INFO, "msg")
+		got = append(got, ok)
+	}
+
+	want := []bool{true, true, false, false, true, false, false, true}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("call %d: Allow = %v, want %v (sequence %v)", i, got[i], want[i], got)
+			break
+		}
+	}
+}
+
+// TestTailSamplerReportsDroppedCount checks that the dropped count surfaced
+// on a passing call covers exactly the suppressed calls since the last pass.
+func TestTailSamplerReportsDroppedCount(t *testing.T) {
+	s := NewTailSampler(1, 2)
+
+	s.Allow(INFO, "msg")               // 1: pass (within First)
+	s.Allow(INFO, "msg")               // 2: drop
+	_, dropped := s.Allow(INFO, "msg") // 3: pass (every 2nd after First), should report 1 dropped
+	if dropped != 1 {
+		t.Errorf("dropped = %d, want 1", dropped)
+	}
+}
+
+// TestTailSamplerNewWindowResetsCount checks that a new one-second window
+// restarts the first-N allowance.
+func TestTailSamplerNewWindowResetsCount(t *testing.T) {
+	s := NewTailSampler(1, 100)
+
+	if ok, _ := s.Allow(INFO, "msg"); !ok {
+		t.Fatal("first call: want true")
+	}
+	if ok, _ := s.Allow(INFO, "msg"); ok {
+		t.Fatal("second call in same window: want false")
+	}
+
+	s.mu.Lock()
+	el := s.state[INFO+"|msg"]
+	el.Value.(*tailEntry).state.windowStart = time.Now().Add(-2 * time.Second)
+	s.mu.Unlock()
+
+	if ok, _ := s.Allow(INFO, "msg"); !ok {
+		t.Fatal("first call in new window: want true")
+	}
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// slogHandler implements slog.Handler over a Logger. Groups opened with
+// WithGroup are tracked as a dot-joined key prefix; slog.Group attrs
+// produced within a single Handle call are translated into true nested
+// Payload maps.
+type slogHandler struct {
+	logger *Logger
+	prefix string
+}
+
+// SlogHandler returns an slog.Handler that routes records through this
+// package's DefaultLogger, letting code that logs via log/slog end up in
+// the same structured pipeline.
+func SlogHandler() slog.Handler {
+	return &slogHandler{logger: DefaultLogger}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	l := slogLevelToOurs(level)
+	return levelRanks[l] >= levelRanks[globalConfig.LogLevel]
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	fields := make([]Field, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		fields = append(fields, h.attrToField(a))
+		return true
+	})
+	h.logger.log(slogLevelToOurs(r.Level), r.Message, fields)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make([]Field, 0, len(attrs))
+	for _, a := range attrs {
+		fields = append(fields, h.attrToField(a))
+	}
+	return &slogHandler{logger: h.logger.With(fields...), prefix: h.prefix}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	prefix := name
+	if h.prefix != "" {
+		prefix = h.prefix + "." + name
+	}
+	return &slogHandler{logger: h.logger, prefix: prefix}
+}
+
+func (h *slogHandler) attrToField(a slog.Attr) Field {
+	key := a.Key
+	if h.prefix != "" {
+		key = h.prefix + "." + key
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		return Any(key, slogGroupToMap(a.Value.Group()))
+	}
+	if key == "error" {
+		if err, ok := a.Value.Any().(error); ok {
+			return Err(err)
+		}
+	}
+	return Any(key, a.Value.Any())
+}
+
+func slogGroupToMap(attrs []slog.Attr) map[string]interface{} {
+	m := make(map[string]interface{}, len(attrs))
+	for _, a := range attrs {
+		if a.Value.Kind() == slog.KindGroup {
+			m[a.Key] = slogGroupToMap(a.Value.Group())
+		} else {
+			m[a.Key] = a.Value.Any()
+		}
+	}
+	return m
+}
+
+func slogLevelToOurs(level slog.Level) string {
+	switch {
+	case level < slog.LevelInfo:
+		return DEBUG
+	case level < slog.LevelWarn:
+		return INFO
+	case level < slog.LevelError:
+		return WARN
+	default:
+		return ERROR
+	}
+}
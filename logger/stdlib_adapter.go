@@ -0,0 +1,25 @@
+package logger
+
+import (
+	stdlog "log"
+	"strings"
+)
+
+// stdLogWriter adapts io.Writer to feed whole lines from the stdlib log
+// package into this logger at INFO level; the stdlib logger has no concept
+// of level, so everything it emits is treated as informational.
+type stdLogWriter struct {
+	logger *Logger
+}
+
+func (w stdLogWriter) Write(p []byte) (int, error) {
+	w.logger.Info(strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
+
+// StdLogger returns a *log.Logger that routes everything written to it
+// through this package's pipeline, so third-party code still using the
+// stdlib logger ends up in the same structured output.
+func StdLogger() *stdlog.Logger {
+	return stdlog.New(stdLogWriter{logger: DefaultLogger}, "", 0)
+}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// Syslog facility codes (RFC 5424 section 6.2.1).
+const (
+	FacilityKern   = 0
+	FacilityUser   = 1
+	FacilityDaemon = 3
+	FacilityAuth   = 4
+	FacilitySyslog = 5
+	FacilityLocal0 = 16
+	FacilityLocal1 = 17
+	FacilityLocal2 = 18
+	FacilityLocal3 = 19
+	FacilityLocal4 = 20
+	FacilityLocal5 = 21
+	FacilityLocal6 = 22
+	FacilityLocal7 = 23
+)
+
+var syslogSeverity = map[string]int{
+	DEBUG: 7,
+	INFO:  6,
+	WARN:  4,
+	ERROR: 3,
+	FATAL: 2,
+}
+
+// SyslogWriter sends entries to a syslog daemon as RFC 5424 messages over
+// UDP, TCP, or a local unix socket.
+type SyslogWriter struct {
+	Network  string // "udp", "tcp", or "unix"
+	Addr     string // host:port, or socket path when Network is "unix"
+	Facility int
+	Hostname string
+	AppName  string
+
+	conn net.Conn
+}
+
+// NewSyslogWriter dials the syslog daemon at addr over network ("udp",
+// "tcp", or "unix") and returns a ready-to-use writer.
+func NewSyslogWriter(network, addr string, facility int, appName string) (*SyslogWriter, error) {
+	conn, err := net.Dial(network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("syslog: dial %s %s: %w", network, addr, err)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+	return &SyslogWriter{
+		Network:  network,
+		Addr:     addr,
+		Facility: facility,
+		Hostname: hostname,
+		AppName:  appName,
+		conn:     conn,
+	}, nil
+}
+
+func (w *SyslogWriter) Write(entry LogEntry) error {
+	severity, ok := syslogSeverity[entry.Level]
+	if !ok {
+		severity = syslogSeverity[INFO]
+	}
+	pri := w.Facility*8 + severity
+
+	ts, err := time.Parse(time.RFC3339, entry.Timestamp)
+	if err != nil {
+		ts = time.Now()
+	}
+
+	msg := entry.Message
+	if entry.Error != "" {
+		msg = fmt.Sprintf("%s error=%q", msg, entry.Error)
+	}
+	for k, v := range entry.Payload {
+		msg = fmt.Sprintf("%s %s=%v", msg, k, v)
+	}
+
+	line := fmt.Sprintf("<%d>1 %s %s %s %d - - %s\n",
+		pri, ts.UTC().Format(time.RFC3339), w.Hostname, w.AppName, entry.ProcessID, msg)
+
+	_, err = w.conn.Write([]byte(line))
+	return err
+}
+
+func (w *SyslogWriter) Close() error {
+	return w.conn.Close()
+}
@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+)
+
+// TestSyslogWriterFramesRFC5424Message checks the PRI value and that the
+// message body carries the entry's message, error, and payload fields.
+func TestSyslogWriterFramesRFC5424Message(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket: %v", err)
+	}
+	defer listener.Close()
+
+	w, err := NewSyslogWriter("udp", listener.LocalAddr().String(), FacilityLocal0, "myapp")
+	if err != nil {
+		t.Fatalf("NewSyslogWriter: %v", err)
+	}
+	defer w.Close()
+
+	entry := LogEntry{
+		Timestamp: "2024-01-01T00:00:00Z",
+		Level:     ERROR,
+		Message:   "disk full",
+		Error:     "no space left on device",
+		Payload:   map[string]interface{}{"volume": "/data"},
+		ProcessID: 1234,
+	}
+	if err := w.Write(entry); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	buf := make([]byte, 1024)
+	n, _, err := listener.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+	line := string(buf[:n])
+
+	wantPRI := FacilityLocal0*8 + 3 // ERROR severity is 3
+	wantPrefix := fmt.Sprintf("<%d>1 ", wantPRI)
+	if !strings.HasPrefix(line, wantPrefix) {
+		t.Errorf("line = %q, want prefix %q", line, wantPrefix)
+	}
+	if !strings.Contains(line, "myapp") {
+		t.Errorf("line = %q, want app name %q", line, "myapp")
+	}
+	if !strings.Contains(line, "disk full") {
+		t.Errorf("line = %q, want message %q", line, "disk full")
+	}
+	if !strings.Contains(line, `error="no space left on device"`) {
+		t.Errorf("line = %q, want quoted error field", line)
+	}
+	if !strings.Contains(line, "volume=/data") {
+		t.Errorf("line = %q, want payload field volume=/data", line)
+	}
+}
@@ -0,0 +1,7 @@
+//go:build darwin || freebsd || netbsd || openbsd
+
+package logger
+
+import "golang.org/x/sys/unix"
+
+const ioctlTermiosReq = unix.TIOCGETA
@@ -0,0 +1,10 @@
+//go:build !windows && !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package logger
+
+// IsTerminal always reports false on platforms without a known ioctl-based
+// terminal check (e.g. solaris, dragonfly, plan9, js/wasm). ConsoleWriter
+// falls back to uncolored output there.
+func IsTerminal(fd uintptr) bool {
+	return false
+}
@@ -0,0 +1,11 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package logger
+
+import "golang.org/x/sys/unix"
+
+// IsTerminal reports whether fd refers to a terminal.
+func IsTerminal(fd uintptr) bool {
+	_, err := unix.IoctlGetTermios(int(fd), ioctlTermiosReq)
+	return err == nil
+}
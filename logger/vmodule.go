@@ -0,0 +1,181 @@
+package logger
+
+import (
+	"container/list"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// vmoduleRule is a single "pattern=LEVEL" entry from SetVModule. pattern is
+// either an exact "dir/file.go" path or a "dir/*" prefix, matched against
+// the trimmed caller file (see trimFilePath).
+type vmoduleRule struct {
+	pattern string
+	rank    int
+}
+
+var (
+	vmoduleMu    sync.RWMutex
+	vmoduleRules []vmoduleRule
+	vCache       = newVerbosityCache(1024)
+)
+
+// SetVModule configures per-file/per-package log level overrides, glog
+// -vmodule style, e.g.:
+//
+//	logger.SetVModule("auth/*=DEBUG,db/query.go=WARN")
+//
+// Rules are evaluated in the order given; the first match wins. Calling
+// SetVModule again replaces the previous rule set, and invalidates the V()
+// cache.
+func SetVModule(spec string) error {
+	var rules []vmoduleRule
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("logger: invalid vmodule entry %q, want pattern=LEVEL", part)
+		}
+		pattern := strings.TrimSpace(kv[0])
+		level := strings.TrimSpace(kv[1])
+		rank, ok := levelRanks[level]
+		if !ok {
+			return fmt.Errorf("logger: invalid vmodule level %q in %q", level, part)
+		}
+		rules = append(rules, vmoduleRule{pattern: pattern, rank: rank})
+	}
+
+	vmoduleMu.Lock()
+	vmoduleRules = rules
+	vmoduleMu.Unlock()
+	vCache.purge()
+	return nil
+}
+
+// matchVModule returns the rank of the first vmodule rule matching file,
+// and whether any rule matched.
+func matchVModule(file string) (int, bool) {
+	vmoduleMu.RLock()
+	defer vmoduleMu.RUnlock()
+	for _, r := range vmoduleRules {
+		if vmodulePatternMatches(r.pattern, file) {
+			return r.rank, true
+		}
+	}
+	return 0, false
+}
+
+func vmodulePatternMatches(pattern, file string) bool {
+	if dir, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(file, dir+"/")
+	}
+	if pattern == "*" {
+		return true
+	}
+	return pattern == file
+}
+
+// maxLevelRank is the rank of the least verbose level (FATAL), used to
+// convert a vmodule rank into a V() verbosity budget.
+const maxLevelRank = 5
+
+// verbosityBudget returns how many V-levels are enabled for file: the
+// higher a file's effective level name sits toward DEBUG, the larger its
+// budget (DEBUG => 4, down to FATAL => 0).
+func verbosityBudget(file string) int {
+	if rank, ok := matchVModule(file); ok {
+		return maxLevelRank - rank
+	}
+	return maxLevelRank - levelRanks[globalConfig.LogLevel]
+}
+
+// V reports whether verbosity-level debug logging is enabled for the
+// caller's file, so call sites can gate expensive debug work:
+//
+//	if logger.V(2) {
+//	    logger.Debug("cache state", expensiveDump())
+//	}
+//
+// Results are cached per (file, level) since the pattern match only needs
+// to run once per call site.
+func V(level int) bool {
+	file, _, _ := getCallerInfo(2)
+	if enabled, ok := vCache.get(file, level); ok {
+		return enabled
+	}
+	enabled := level <= verbosityBudget(file)
+	vCache.put(file, level, enabled)
+	return enabled
+}
+
+// verbosityCache is a small LRU cache mapping (file, level) to whether V
+// reported that level enabled for that file.
+type verbosityCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[verbosityCacheKey]*list.Element
+}
+
+type verbosityCacheKey struct {
+	file  string
+	level int
+}
+
+type verbosityCacheEntry struct {
+	key     verbosityCacheKey
+	enabled bool
+}
+
+func newVerbosityCache(capacity int) *verbosityCache {
+	return &verbosityCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[verbosityCacheKey]*list.Element),
+	}
+}
+
+func (c *verbosityCache) get(file string, level int) (bool, bool) {
+	key := verbosityCacheKey{file: file, level: level}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		return el.Value.(*verbosityCacheEntry).enabled, true
+	}
+	return false, false
+}
+
+func (c *verbosityCache) put(file string, level int, enabled bool) {
+	key := verbosityCacheKey{file: file, level: level}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*verbosityCacheEntry).enabled = enabled
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&verbosityCacheEntry{key: key, enabled: enabled})
+	c.items[key] = el
+
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*verbosityCacheEntry).key)
+		}
+	}
+}
+
+func (c *verbosityCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ll = list.New()
+	c.items = make(map[verbosityCacheKey]*list.Element)
+}
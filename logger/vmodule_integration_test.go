@@ -0,0 +1,48 @@
+package logger_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Yaanthrik/sdk/internal/auth"
+	"github.com/Yaanthrik/sdk/logger"
+)
+
+type captureWriter struct {
+	entries []logger.LogEntry
+}
+
+func (c *captureWriter) Write(entry logger.LogEntry) error {
+	c.entries = append(c.entries, entry)
+	return nil
+}
+
+func (c *captureWriter) Close() error { return nil }
+
+// TestVModuleMatchesRealCallerPackage exercises SetVModule against a call
+// site in a separate package, the scenario from the request's own example
+// (logger.SetVModule("auth/*=DEBUG,...")). With the global level at WARN,
+// a DEBUG log from the "auth" package must still pass because of the
+// override, while a DEBUG log from this package must not.
+func TestVModuleMatchesRealCallerPackage(t *testing.T) {
+	cap := &captureWriter{}
+	logger.Initialize(logger.Config{Writers: []logger.Writer{cap}, LogLevel: logger.WARN})
+	t.Cleanup(func() {
+		logger.SetVModule("")
+		logger.Initialize(logger.Config{Writers: []logger.Writer{logger.NewConsoleWriter(os.Stdout, false)}, LogLevel: logger.DEBUG})
+	})
+
+	if err := logger.SetVModule("auth/*=DEBUG"); err != nil {
+		t.Fatalf("SetVModule: %v", err)
+	}
+
+	auth.LogDebug("debug from auth subsystem")
+	logger.Debug("debug from logger_test package", nil)
+
+	if len(cap.entries) != 1 {
+		t.Fatalf("got %d entries, want 1 (only the auth-package DEBUG should pass): %+v", len(cap.entries), cap.entries)
+	}
+	if cap.entries[0].Message != "debug from auth subsystem" {
+		t.Errorf("unexpected entry passed through: %+v", cap.entries[0])
+	}
+}
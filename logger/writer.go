@@ -0,0 +1,151 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+)
+
+// Writer is implemented by log sinks. Write receives a fully populated
+// LogEntry and is responsible for formatting and delivering it. Close
+// releases any resources (file handles, sockets, goroutines) held by the
+// writer and is called once during shutdown.
+type Writer interface {
+	Write(entry LogEntry) error
+	Close() error
+}
+
+// Flusher is an optional interface a Writer can implement when it buffers
+// entries internally. Callers that need delivery guarantees (e.g. the async
+// pipeline on shutdown) type-assert for it.
+type Flusher interface {
+	Flush() error
+}
+
+// LeveledWriter pairs a Writer with the minimum level it should receive,
+// letting a MultiWriter fan the same stream out at different verbosities
+// (e.g. WARN+ to syslog while DEBUG still goes to a file).
+type LeveledWriter struct {
+	Writer   Writer
+	MinLevel string
+}
+
+// MultiWriter fans out a LogEntry to every configured writer, skipping
+// writers whose MinLevel is above the entry's level.
+type MultiWriter struct {
+	writers []LeveledWriter
+}
+
+// NewMultiWriter builds a MultiWriter from the given leveled writers.
+func NewMultiWriter(writers ...LeveledWriter) *MultiWriter {
+	return &MultiWriter{writers: writers}
+}
+
+// Write delivers entry to every writer whose MinLevel it satisfies. Errors
+// from individual writers are joined rather than aborting the fan-out, so a
+// broken syslog connection doesn't stop file logging.
+func (m *MultiWriter) Write(entry LogEntry) error {
+	var errs []error
+	for _, lw := range m.writers {
+		if !levelAtLeast(entry.Level, lw.MinLevel) {
+			continue
+		}
+		if err := lw.Writer.Write(entry); err != nil {
+			errs = append(errs, fmt.Errorf("%T: %w", lw.Writer, err))
+		}
+	}
+	return joinErrors(errs)
+}
+
+// Close closes every writer, collecting any errors encountered.
+func (m *MultiWriter) Close() error {
+	var errs []error
+	for _, lw := range m.writers {
+		if err := lw.Writer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%T: %w", lw.Writer, err))
+		}
+	}
+	return joinErrors(errs)
+}
+
+func joinErrors(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	msg := errs[0].Error()
+	for _, e := range errs[1:] {
+		msg += "; " + e.Error()
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// levelAtLeast reports whether level is at least as severe as min.
+func levelAtLeast(level, min string) bool {
+	if min == "" {
+		return true
+	}
+	return levelRanks[level] >= levelRanks[min]
+}
+
+// ConsoleWriter writes entries to an *os.File (typically os.Stdout or
+// os.Stderr), colorizing the level when the target is a terminal.
+type ConsoleWriter struct {
+	Out        *os.File
+	JSONFormat bool
+	Color      bool // colorize the level; NewConsoleWriter sets it when Out is a terminal. NoColor overrides it
+	NoColor    bool
+}
+
+// NewConsoleWriter builds a ConsoleWriter that auto-detects color support
+// from the target file descriptor.
+func NewConsoleWriter(out *os.File, jsonFormat bool) *ConsoleWriter {
+	return &ConsoleWriter{Out: out, JSONFormat: jsonFormat, Color: IsTerminal(out.Fd())}
+}
+
+var levelColors = map[string]string{
+	DEBUG: "\033[36m", // cyan
+	INFO:  "\033[32m", // green
+	WARN:  "\033[33m", // yellow
+	ERROR: "\033[31m", // red
+	FATAL: "\033[35m", // magenta
+}
+
+const colorReset = "\033[0m"
+
+func (w *ConsoleWriter) Write(entry LogEntry) error {
+	if w.JSONFormat {
+		data, err := marshalEntry(entry)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w.Out, string(data))
+		return err
+	}
+
+	level := entry.Level
+	if w.Color && !w.NoColor {
+		if c, ok := levelColors[entry.Level]; ok {
+			level = c + entry.Level + colorReset
+		}
+	}
+
+	if _, err := fmt.Fprintf(w.Out, "[%s] %s %s:%d %s - %s\n",
+		entry.Timestamp, level, entry.File, entry.Line, entry.Function, entry.Message); err != nil {
+		return err
+	}
+	if entry.Error != "" {
+		if _, err := fmt.Fprintf(w.Out, "Error: %s\n", entry.Error); err != nil {
+			return err
+		}
+	}
+	if entry.Payload != nil {
+		if _, err := fmt.Fprintf(w.Out, "Payload: %+v\n", entry.Payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close is a no-op for ConsoleWriter; os.Stdout/os.Stderr are not ours to close.
+func (w *ConsoleWriter) Close() error {
+	return nil
+}
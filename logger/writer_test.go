@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+)
+
+type failingWriter struct {
+	writeErr error
+	closeErr error
+}
+
+func (f *failingWriter) Write(LogEntry) error { return f.writeErr }
+func (f *failingWriter) Close() error         { return f.closeErr }
+
+func TestMultiWriterFansOutToAllMatchingWriters(t *testing.T) {
+	a := &captureWriter{}
+	b := &captureWriter{}
+	mw := NewMultiWriter(
+		LeveledWriter{Writer: a, MinLevel: DEBUG},
+		LeveledWriter{Writer: b, MinLevel: WARN},
+	)
+
+	if err := mw.Write(LogEntry{Level: INFO, Message: "info entry"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if len(a.entries) != 1 {
+		t.Errorf("writer a got %d entries, want 1 (MinLevel DEBUG passes INFO)", len(a.entries))
+	}
+	if len(b.entries) != 0 {
+		t.Errorf("writer b got %d entries, want 0 (MinLevel WARN should skip INFO)", len(b.entries))
+	}
+
+	if err := mw.Write(LogEntry{Level: ERROR, Message: "error entry"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if len(a.entries) != 2 || len(b.entries) != 1 {
+		t.Errorf("after ERROR entry: a=%d b=%d, want a=2 b=1", len(a.entries), len(b.entries))
+	}
+}
+
+func TestMultiWriterJoinsErrorsWithoutAbortingFanout(t *testing.T) {
+	ok := &captureWriter{}
+	bad := &failingWriter{writeErr: errors.New("connection reset")}
+	mw := NewMultiWriter(
+		LeveledWriter{Writer: bad},
+		LeveledWriter{Writer: ok},
+	)
+
+	err := mw.Write(LogEntry{Level: INFO, Message: "hi"})
+	if err == nil {
+		t.Fatal("Write: want a joined error from the failing writer, got nil")
+	}
+	if len(ok.entries) != 1 {
+		t.Errorf("healthy writer got %d entries, want 1 (a failing sibling must not abort fan-out)", len(ok.entries))
+	}
+}
+
+func TestMultiWriterCloseClosesAllAndJoinsErrors(t *testing.T) {
+	a := &failingWriter{closeErr: errors.New("close a failed")}
+	b := &failingWriter{closeErr: errors.New("close b failed")}
+	mw := NewMultiWriter(LeveledWriter{Writer: a}, LeveledWriter{Writer: b})
+
+	err := mw.Close()
+	if err == nil {
+		t.Fatal("Close: want a joined error, got nil")
+	}
+}
+
+func TestLevelAtLeast(t *testing.T) {
+	cases := []struct {
+		level, min string
+		want       bool
+	}{
+		{INFO, "", true},
+		{DEBUG, INFO, false},
+		{INFO, INFO, true},
+		{ERROR, INFO, true},
+	}
+	for _, c := range cases {
+		if got := levelAtLeast(c.level, c.min); got != c.want {
+			t.Errorf("levelAtLeast(%q, %q) = %v, want %v", c.level, c.min, got, c.want)
+		}
+	}
+}